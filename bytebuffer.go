@@ -0,0 +1,204 @@
+package easyringbuffer
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrBufferFull is returned by ByteBuffer.Write when MaxSize is set and
+// accommodating the write would grow the buffer past it.
+var ErrBufferFull = errors.New("byte buffer exceeds MaxSize")
+
+// ByteBuffer is a contiguous, wrap-around byte buffer geared toward
+// network code that needs to inspect pending bytes without copying them.
+// Unlike ByteRingBuffer, it grows on write (optionally capped by a
+// MaxSize) instead of rejecting writes once full.
+type ByteBuffer struct {
+	buffer  []byte
+	head    int
+	tail    int
+	size    int
+	maxSize int
+	mu      sync.Mutex
+}
+
+// NewByteBuffer creates a new ByteBuffer with the given initial capacity.
+// maxSize caps how large the buffer may grow; a maxSize of 0 means
+// unbounded growth.
+func NewByteBuffer(initialCapacity, maxSize int) *ByteBuffer {
+	if initialCapacity <= 0 {
+		initialCapacity = 64
+	}
+	return &ByteBuffer{
+		buffer:  make([]byte, initialCapacity),
+		maxSize: maxSize,
+	}
+}
+
+// Write appends p to the buffer, growing the backing array if needed.
+// It returns ErrBufferFull without writing anything if MaxSize is set and
+// the write would exceed it.
+func (b *ByteBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	need := b.size + len(p)
+	if b.maxSize > 0 && need > b.maxSize {
+		return 0, ErrBufferFull
+	}
+	if need > len(b.buffer) {
+		b.grow(need)
+	}
+
+	n := len(p)
+	first := len(b.buffer) - b.tail
+	if first > n {
+		first = n
+	}
+	copy(b.buffer[b.tail:], p[:first])
+	if n > first {
+		copy(b.buffer, p[first:])
+	}
+	b.tail = (b.tail + n) % len(b.buffer)
+	b.size += n
+
+	return n, nil
+}
+
+// grow reallocates the backing array so it can hold at least minCap
+// bytes, compacting the existing contents to start at index 0.
+func (b *ByteBuffer) grow(minCap int) {
+	newCap := len(b.buffer) * 2
+	if newCap < minCap {
+		newCap = minCap
+	}
+	if b.maxSize > 0 && newCap > b.maxSize && minCap <= b.maxSize {
+		newCap = b.maxSize
+	}
+
+	newBuffer := make([]byte, newCap)
+	first := len(b.buffer) - b.head
+	if first > b.size {
+		first = b.size
+	}
+	copy(newBuffer, b.buffer[b.head:b.head+first])
+	if b.size > first {
+		copy(newBuffer[first:], b.buffer[:b.size-first])
+	}
+
+	b.buffer = newBuffer
+	b.head = 0
+	b.tail = b.size
+}
+
+// Read copies up to min(len(p), Buffered()) bytes into p, advancing the
+// read pointer. It returns io.EOF when the buffer is empty.
+func (b *ByteBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.size == 0 {
+		return 0, io.EOF
+	}
+
+	n := len(p)
+	if n > b.size {
+		n = b.size
+	}
+
+	first := len(b.buffer) - b.head
+	if first > n {
+		first = n
+	}
+	copy(p[:first], b.buffer[b.head:])
+	if n > first {
+		copy(p[first:n], b.buffer[:n-first])
+	}
+	b.head = (b.head + n) % len(b.buffer)
+	b.size -= n
+
+	return n, nil
+}
+
+// ReadByte reads and returns a single byte, implementing io.ByteReader.
+func (b *ByteBuffer) ReadByte() (byte, error) {
+	var p [1]byte
+	n, err := b.Read(p[:])
+	if n == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return 0, err
+	}
+	return p[0], nil
+}
+
+// WriteByte appends a single byte, implementing io.ByteWriter.
+func (b *ByteBuffer) WriteByte(c byte) error {
+	_, err := b.Write([]byte{c})
+	return err
+}
+
+// Peek returns up to n unread bytes without advancing the read pointer or
+// copying them. head is the run from the read pointer to the end of the
+// backing array and tail is the wrapped portion, if any. Both slices
+// alias the internal buffer and are only valid until the next Write,
+// Read, or Discard.
+func (b *ByteBuffer) Peek(n int) (head, tail []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n > b.size {
+		n = b.size
+	}
+
+	first := len(b.buffer) - b.head
+	if first > n {
+		first = n
+	}
+	head = b.buffer[b.head : b.head+first]
+	if n > first {
+		tail = b.buffer[:n-first]
+	}
+	return head, tail
+}
+
+// Discard advances the read pointer by n bytes, as if they had been read
+// and thrown away. It panics if n exceeds the number of buffered bytes.
+func (b *ByteBuffer) Discard(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n > b.size {
+		panic("easyringbuffer: Discard count exceeds buffered bytes")
+	}
+	b.head = (b.head + n) % len(b.buffer)
+	b.size -= n
+}
+
+// Bytes returns the current contents as a single contiguous slice,
+// allocating only when the data wraps around the end of the backing
+// array.
+func (b *ByteBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	first := len(b.buffer) - b.head
+	if first >= b.size {
+		return b.buffer[b.head : b.head+b.size]
+	}
+
+	out := make([]byte, b.size)
+	copy(out, b.buffer[b.head:])
+	copy(out[first:], b.buffer[:b.size-first])
+	return out
+}
+
+// Buffered returns the number of bytes currently available to read.
+func (b *ByteBuffer) Buffered() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.size
+}
@@ -0,0 +1,131 @@
+package easyringbuffer
+
+import (
+	"io"
+	"sync"
+)
+
+// ByteRingBuffer is a thread-safe, fixed-capacity byte ring buffer that
+// implements io.Reader and io.Writer. It lets callers stream bytes in and
+// out without the goroutine-per-side blocking that io.Pipe requires.
+type ByteRingBuffer struct {
+	buffer   []byte
+	capacity int
+	size     int
+	head     int
+	tail     int
+	closed   bool
+	mu       sync.Mutex
+}
+
+// NewByteRingBuffer creates a new byte ring buffer with the specified capacity.
+func NewByteRingBuffer(capacity int) *ByteRingBuffer {
+	if capacity <= 0 {
+		panic("capacity must be greater than 0")
+	}
+	return &ByteRingBuffer{
+		buffer:   make([]byte, capacity),
+		capacity: capacity,
+	}
+}
+
+// Write copies as many bytes from p as fit in the buffer, advancing the
+// write cursor and handling wraparound with two copies when the write
+// straddles the end of the backing array. It returns the number of bytes
+// actually written, along with io.ErrShortWrite when len(p) > Free().
+func (rb *ByteRingBuffer) Write(p []byte) (int, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if rb.closed {
+		return 0, ErrRingBufferClosed
+	}
+
+	free := rb.capacity - rb.size
+	n := len(p)
+	short := false
+	if n > free {
+		n = free
+		short = true
+	}
+
+	if n > 0 {
+		first := rb.capacity - rb.tail
+		if first > n {
+			first = n
+		}
+		copy(rb.buffer[rb.tail:], p[:first])
+		if n > first {
+			copy(rb.buffer, p[first:n])
+		}
+		rb.tail = (rb.tail + n) % rb.capacity
+		rb.size += n
+	}
+
+	if short {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
+
+// Read copies up to min(len(p), Buffered()) bytes into p, advancing the
+// read cursor. It returns io.EOF only once the buffer is empty and closed.
+func (rb *ByteRingBuffer) Read(p []byte) (int, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if rb.size == 0 {
+		if rb.closed {
+			return 0, io.EOF
+		}
+		return 0, nil
+	}
+
+	n := len(p)
+	if n > rb.size {
+		n = rb.size
+	}
+
+	if n > 0 {
+		first := rb.capacity - rb.head
+		if first > n {
+			first = n
+		}
+		copy(p[:first], rb.buffer[rb.head:])
+		if n > first {
+			copy(p[first:n], rb.buffer[:n-first])
+		}
+		rb.head = (rb.head + n) % rb.capacity
+		rb.size -= n
+	}
+
+	return n, nil
+}
+
+// Buffered returns the number of bytes currently available to read.
+func (rb *ByteRingBuffer) Buffered() int {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	return rb.size
+}
+
+// Free returns the number of bytes that can still be written before the
+// buffer is full.
+func (rb *ByteRingBuffer) Free() int {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	return rb.capacity - rb.size
+}
+
+// Close marks the buffer as closed. Reads continue to drain any buffered
+// bytes, returning io.EOF once empty; writes after Close return
+// ErrRingBufferClosed.
+func (rb *ByteRingBuffer) Close() error {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	rb.closed = true
+	return nil
+}
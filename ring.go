@@ -1,23 +1,34 @@
 package easyringbuffer
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"sync/atomic"
 )
 
 var (
-	ErrRingBufferFull  = errors.New("ring buffer is full")
-	ErrRingBufferEmpty = errors.New("ring buffer is empty")
+	ErrRingBufferFull   = errors.New("ring buffer is full")
+	ErrRingBufferEmpty  = errors.New("ring buffer is empty")
+	ErrRingBufferClosed = errors.New("ring buffer is closed")
 )
 
 // RingBuffer is a high-performance, thread-safe ring buffer.
 type RingBuffer[T any] struct {
-	buffer       []T
-	mask         uint64
-	writePointer uint64
-	readPointer  uint64
-	writeReserve uint64
-	readReserve  uint64
+	buffer          []T
+	mask            uint64
+	writePointer    uint64
+	readPointer     uint64
+	writeReserve    uint64
+	readReserve     uint64
+	closed          uint32
+	overwrite       bool
+	dropped         uint64
+	mu              sync.Mutex
+	notFull         *sync.Cond
+	notEmpty        *sync.Cond
+	notFullWaiters  int32
+	notEmptyWaiters int32
 }
 
 // NewRingBuffer creates a new ring buffer with the specified capacity.
@@ -26,20 +37,69 @@ func NewRingBuffer[T any](capacity uint64) (*RingBuffer[T], error) {
 	if capacity == 0 || (capacity&(capacity-1)) != 0 {
 		return nil, errors.New("capacity must be a power of two")
 	}
-	return &RingBuffer[T]{
+	rb := &RingBuffer[T]{
 		buffer: make([]T, capacity),
 		mask:   capacity - 1,
-	}, nil
+	}
+	rb.notFull = sync.NewCond(&rb.mu)
+	rb.notEmpty = sync.NewCond(&rb.mu)
+	return rb, nil
+}
+
+// NewOverwriting creates a new ring buffer with the specified capacity
+// whose Enqueue, once the buffer is full, evicts the oldest item to make
+// room for the newest rather than returning ErrRingBufferFull. EnqueueN
+// does not evict and still returns ErrRingBufferFull on a full buffer.
+// Use Dropped to see how many items have been evicted this way.
+func NewOverwriting[T any](capacity uint64) (*RingBuffer[T], error) {
+	rb, err := NewRingBuffer[T](capacity)
+	if err != nil {
+		return nil, err
+	}
+	rb.overwrite = true
+	return rb, nil
+}
+
+// Dropped reports how many items have been evicted by overwrite-on-full
+// behavior. It is always zero unless the buffer was created with
+// NewOverwriting.
+func (rb *RingBuffer[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&rb.dropped)
+}
+
+// evictOldest advances the read pointer past the oldest slot, making room
+// for a pending Enqueue. It reports whether it performed the eviction; a
+// false result means a concurrent Dequeue or eviction already claimed that
+// slot, and the caller should reload and retry.
+func (rb *RingBuffer[T]) evictOldest(rp uint64) bool {
+	if !atomic.CompareAndSwapUint64(&rb.readPointer, rp, rp+1) {
+		return false
+	}
+	for !atomic.CompareAndSwapUint64(&rb.readReserve, rp, rp+1) {
+		// Spin-wait for any in-flight Dequeue on this slot to finish.
+	}
+	atomic.AddUint64(&rb.dropped, 1)
+	return true
 }
 
 // Enqueue adds an item to the ring buffer.
-// Returns an error if the buffer is full.
+// Returns an error if the buffer is full, unless the buffer was created
+// with NewOverwriting, in which case the oldest item is evicted instead.
 func (rb *RingBuffer[T]) Enqueue(item T) error {
 	for {
 		wp := atomic.LoadUint64(&rb.writePointer)
 		rp := atomic.LoadUint64(&rb.readReserve)
 		if wp-rp >= uint64(len(rb.buffer)) {
-			return ErrRingBufferFull
+			if !rb.overwrite {
+				return ErrRingBufferFull
+			}
+			if rp > wp {
+				// writePointer moved on after wp was loaded, so wp-rp
+				// underflowed; the buffer is not necessarily full.
+				continue
+			}
+			rb.evictOldest(rp)
+			continue
 		}
 		if atomic.CompareAndSwapUint64(&rb.writePointer, wp, wp+1) {
 			index := wp & rb.mask
@@ -48,12 +108,37 @@ func (rb *RingBuffer[T]) Enqueue(item T) error {
 			for !atomic.CompareAndSwapUint64(&rb.writeReserve, wp, wp+1) {
 				// Spin-wait
 			}
+			rb.signalNotEmpty()
 			return nil
 		}
 		// Failed to reserve write pointer, retry
 	}
 }
 
+// signalNotEmpty wakes any DequeueBlocking callers, skipping the mutex
+// entirely when none are waiting so the lock-free fast path stays
+// lock-free under uncontended use.
+func (rb *RingBuffer[T]) signalNotEmpty() {
+	if atomic.LoadInt32(&rb.notEmptyWaiters) == 0 {
+		return
+	}
+	rb.mu.Lock()
+	rb.notEmpty.Broadcast()
+	rb.mu.Unlock()
+}
+
+// signalNotFull wakes any EnqueueBlocking callers, skipping the mutex
+// entirely when none are waiting so the lock-free fast path stays
+// lock-free under uncontended use.
+func (rb *RingBuffer[T]) signalNotFull() {
+	if atomic.LoadInt32(&rb.notFullWaiters) == 0 {
+		return
+	}
+	rb.mu.Lock()
+	rb.notFull.Broadcast()
+	rb.mu.Unlock()
+}
+
 // Dequeue removes and returns an item from the ring buffer.
 // Returns an error if the buffer is empty.
 func (rb *RingBuffer[T]) Dequeue() (T, error) {
@@ -74,12 +159,199 @@ func (rb *RingBuffer[T]) Dequeue() (T, error) {
 			for !atomic.CompareAndSwapUint64(&rb.readReserve, rp, rp+1) {
 				// Spin-wait
 			}
+			rb.signalNotFull()
 			return item, nil
 		}
 		// Failed to reserve read pointer, retry
 	}
 }
 
+// EnqueueN reserves a range of slots with a single CAS on the write
+// pointer and fills as many items as fit, amortizing the CAS and
+// spin-wait cost across the whole batch. It returns the number of items
+// actually enqueued; a partial batch returns n < len(items) with no
+// error, and ErrRingBufferFull is only returned when n == 0.
+func (rb *RingBuffer[T]) EnqueueN(items []T) (int, error) {
+	for {
+		wp := atomic.LoadUint64(&rb.writePointer)
+		rp := atomic.LoadUint64(&rb.readReserve)
+		free := uint64(len(rb.buffer)) - (wp - rp)
+		if free == 0 {
+			return 0, ErrRingBufferFull
+		}
+
+		n := uint64(len(items))
+		if n > free {
+			n = free
+		}
+
+		if atomic.CompareAndSwapUint64(&rb.writePointer, wp, wp+n) {
+			for i := uint64(0); i < n; i++ {
+				rb.buffer[(wp+i)&rb.mask] = items[i]
+			}
+			// Update the write reserve pointer
+			for !atomic.CompareAndSwapUint64(&rb.writeReserve, wp, wp+n) {
+				// Spin-wait
+			}
+			rb.signalNotEmpty()
+			return int(n), nil
+		}
+		// Failed to reserve write range, retry
+	}
+}
+
+// DequeueN reserves a range of slots with a single CAS on the read
+// pointer and drains as many items into dst as are available, mirroring
+// EnqueueN. It returns the number of items actually dequeued; a partial
+// batch returns n < len(dst) with no error, and ErrRingBufferEmpty is
+// only returned when n == 0.
+func (rb *RingBuffer[T]) DequeueN(dst []T) (int, error) {
+	for {
+		rp := atomic.LoadUint64(&rb.readPointer)
+		wp := atomic.LoadUint64(&rb.writeReserve)
+		if rp >= wp {
+			return 0, ErrRingBufferEmpty
+		}
+		avail := wp - rp
+
+		n := uint64(len(dst))
+		if n > avail {
+			n = avail
+		}
+
+		if atomic.CompareAndSwapUint64(&rb.readPointer, rp, rp+n) {
+			var zero T
+			for i := uint64(0); i < n; i++ {
+				index := (rp + i) & rb.mask
+				dst[i] = rb.buffer[index]
+				rb.buffer[index] = zero
+			}
+			// Update the read reserve pointer
+			for !atomic.CompareAndSwapUint64(&rb.readReserve, rp, rp+n) {
+				// Spin-wait
+			}
+			rb.signalNotFull()
+			return int(n), nil
+		}
+		// Failed to reserve read range, retry
+	}
+}
+
+// EnqueueBlocking adds an item to the ring buffer, parking the caller
+// while the buffer is full instead of returning ErrRingBufferFull. It
+// returns ctx.Err() if ctx is cancelled first, or ErrRingBufferClosed if
+// Close is called while waiting.
+func (rb *RingBuffer[T]) EnqueueBlocking(ctx context.Context, item T) error {
+	if atomic.LoadUint32(&rb.closed) == 1 {
+		return ErrRingBufferClosed
+	}
+	if err := rb.Enqueue(item); err == nil || !errors.Is(err, ErrRingBufferFull) {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			rb.mu.Lock()
+			rb.notFull.Broadcast()
+			rb.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	atomic.AddInt32(&rb.notFullWaiters, 1)
+	defer atomic.AddInt32(&rb.notFullWaiters, -1)
+
+	for {
+		// Enqueue takes rb.mu itself on its success path to broadcast, so
+		// it must never be called while rb.mu is already held here.
+		err := rb.Enqueue(item)
+		if err == nil || !errors.Is(err, ErrRingBufferFull) {
+			return err
+		}
+
+		rb.mu.Lock()
+		for rb.IsFull() && atomic.LoadUint32(&rb.closed) == 0 && ctx.Err() == nil {
+			rb.notFull.Wait()
+		}
+		closed := atomic.LoadUint32(&rb.closed) == 1
+		ctxErr := ctx.Err()
+		rb.mu.Unlock()
+
+		if closed {
+			return ErrRingBufferClosed
+		}
+		if ctxErr != nil {
+			return ctxErr
+		}
+		// A slot may have freed up; loop back and retry Enqueue.
+	}
+}
+
+// DequeueBlocking removes and returns an item from the ring buffer,
+// parking the caller while the buffer is empty instead of returning
+// ErrRingBufferEmpty. It returns ctx.Err() if ctx is cancelled first, or
+// ErrRingBufferClosed once Close is called and the buffer has drained.
+func (rb *RingBuffer[T]) DequeueBlocking(ctx context.Context) (T, error) {
+	if item, err := rb.Dequeue(); err == nil || !errors.Is(err, ErrRingBufferEmpty) {
+		return item, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			rb.mu.Lock()
+			rb.notEmpty.Broadcast()
+			rb.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	atomic.AddInt32(&rb.notEmptyWaiters, 1)
+	defer atomic.AddInt32(&rb.notEmptyWaiters, -1)
+
+	var zeroValue T
+	for {
+		// Dequeue takes rb.mu itself on its success path to broadcast, so
+		// it must never be called while rb.mu is already held here.
+		item, err := rb.Dequeue()
+		if err == nil || !errors.Is(err, ErrRingBufferEmpty) {
+			return item, err
+		}
+
+		rb.mu.Lock()
+		for rb.IsEmpty() && atomic.LoadUint32(&rb.closed) == 0 && ctx.Err() == nil {
+			rb.notEmpty.Wait()
+		}
+		closed := atomic.LoadUint32(&rb.closed) == 1
+		ctxErr := ctx.Err()
+		rb.mu.Unlock()
+
+		if closed && rb.IsEmpty() {
+			return zeroValue, ErrRingBufferClosed
+		}
+		if ctxErr != nil {
+			return zeroValue, ctxErr
+		}
+		// An item may have arrived; loop back and retry Dequeue.
+	}
+}
+
+// Close marks the ring buffer as closed, waking any blocked
+// EnqueueBlocking or DequeueBlocking callers so they return
+// ErrRingBufferClosed once the buffer has drained.
+func (rb *RingBuffer[T]) Close() {
+	atomic.StoreUint32(&rb.closed, 1)
+	rb.mu.Lock()
+	rb.notFull.Broadcast()
+	rb.notEmpty.Broadcast()
+	rb.mu.Unlock()
+}
+
 // GetAt retrieves the item at the given index relative to the read pointer.
 // Index 0 corresponds to the oldest item.
 func (rb *RingBuffer[T]) GetAt(index int) (T, error) {
@@ -94,6 +366,33 @@ func (rb *RingBuffer[T]) GetAt(index int) (T, error) {
 	return rb.buffer[actualIndex], nil
 }
 
+// Snapshot atomically copies the current contents of the ring buffer, in
+// order from oldest to newest. It supersedes repeated GetAt calls, which
+// can observe a torn view when raced by concurrent producers.
+func (rb *RingBuffer[T]) Snapshot() []T {
+	for {
+		rp := atomic.LoadUint64(&rb.readReserve)
+		wp := atomic.LoadUint64(&rb.writeReserve)
+		if rp > wp {
+			// An eviction claimed a slot whose write is still in flight;
+			// retry once it is published.
+			continue
+		}
+		size := wp - rp
+
+		result := make([]T, size)
+		for i := uint64(0); i < size; i++ {
+			result[i] = rb.buffer[(rp+i)&rb.mask]
+		}
+
+		// If either pointer moved while we copied, the buffer may have
+		// been overwritten mid-read; retry for a consistent view.
+		if atomic.LoadUint64(&rb.readReserve) == rp && atomic.LoadUint64(&rb.writeReserve) == wp {
+			return result
+		}
+	}
+}
+
 // IsEmpty checks if the ring buffer is empty.
 func (rb *RingBuffer[T]) IsEmpty() bool {
 	rp := atomic.LoadUint64(&rb.readReserve)
@@ -113,11 +412,25 @@ func (rb *RingBuffer[T]) Capacity() uint64 {
 	return uint64(len(rb.buffer))
 }
 
-// Size returns the current number of items in the ring buffer.
+// Size returns the current number of items in the ring buffer. It retries
+// until it reads a writePointer/readReserve pair from the same instant
+// (mirroring Snapshot's consistency check), since reading them as two
+// independent atomic loads can otherwise observe a torn view while
+// concurrent Enqueue/evictOldest calls are in flight and return a result
+// far above Capacity. A readReserve ahead of writePointer is clamped to 0
+// rather than underflowing.
 func (rb *RingBuffer[T]) Size() uint64 {
-	rp := atomic.LoadUint64(&rb.readReserve)
-	wp := atomic.LoadUint64(&rb.writePointer)
-	return wp - rp
+	for {
+		wp := atomic.LoadUint64(&rb.writePointer)
+		rp := atomic.LoadUint64(&rb.readReserve)
+		if atomic.LoadUint64(&rb.writePointer) != wp {
+			continue
+		}
+		if rp > wp {
+			return 0
+		}
+		return wp - rp
+	}
 }
 
 // Reset clears all items in the ring buffer.
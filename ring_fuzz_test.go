@@ -0,0 +1,253 @@
+package easyringbuffer
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestRingBufferRandomOps drives the MPMC ring buffer through a large
+// number of randomly ordered Enqueue/Dequeue operations and checks the core
+// invariants, via Size/IsEmpty/IsFull/GetAt, after every step. A fixed seed keeps
+// failures reproducible. This is the kind of randomised interleaving that
+// catches off-by-one and wraparound bugs fixed-scenario tests like
+// TestWrapAround miss.
+func TestRingBufferRandomOps(t *testing.T) {
+	const capacity = 256
+	const iterations = 300000
+
+	rb, err := NewRingBuffer[int](capacity)
+	if err != nil {
+		t.Fatalf("Failed to create ring buffer: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	var writtenSoFar, readSoFar uint64
+	var fifo []int
+
+	for i := 0; i < iterations; i++ {
+		if rng.Intn(2) == 0 {
+			if err := rb.Enqueue(i); err == nil {
+				writtenSoFar++
+				fifo = append(fifo, i)
+			} else if !errors.Is(err, ErrRingBufferFull) {
+				t.Fatalf("unexpected Enqueue error: %v", err)
+			}
+		} else {
+			item, err := rb.Dequeue()
+			if err == nil {
+				readSoFar++
+				if len(fifo) == 0 || fifo[0] != item {
+					t.Fatalf("FIFO order violated: got %d, want %d", item, fifo[0])
+				}
+				fifo = fifo[1:]
+			} else if !errors.Is(err, ErrRingBufferEmpty) {
+				t.Fatalf("unexpected Dequeue error: %v", err)
+			}
+		}
+
+		size := rb.Size()
+		if size != writtenSoFar-readSoFar {
+			t.Fatalf("Size() == %d, want %d (written=%d read=%d)", size, writtenSoFar-readSoFar, writtenSoFar, readSoFar)
+		}
+		if size > rb.Capacity() {
+			t.Fatalf("Size() %d exceeds Capacity() %d", size, rb.Capacity())
+		}
+		if rb.IsEmpty() != (size == 0) {
+			t.Fatalf("IsEmpty() == %v, want %v", rb.IsEmpty(), size == 0)
+		}
+		if rb.IsFull() != (size == rb.Capacity()) {
+			t.Fatalf("IsFull() == %v, want %v", rb.IsFull(), size == rb.Capacity())
+		}
+
+		for idx := uint64(0); idx < size; idx++ {
+			got, err := rb.GetAt(int(idx))
+			if err != nil {
+				t.Fatalf("GetAt(%d) unexpected error: %v", idx, err)
+			}
+			if got != fifo[idx] {
+				t.Fatalf("GetAt(%d) == %d, want %d", idx, got, fifo[idx])
+			}
+		}
+	}
+}
+
+// TestRingBufferConcurrentOps drives the MPMC ring buffer with multiple
+// producer and consumer goroutines hammering Enqueue/Dequeue at once. Unlike
+// TestRingBufferRandomOps and FuzzRingBuffer, which complete each operation
+// before starting the next, this exercises real concurrent interleavings of
+// the CAS loops on writePointer/readPointer/writeReserve/readReserve — the
+// only way to catch a readReserve > writePointer / Size() underflow race.
+// Run with -race to also catch any unsynchronized access to rb.buffer.
+func TestRingBufferConcurrentOps(t *testing.T) {
+	const capacity = 1024
+	const numProducers = 8
+	const numConsumers = 8
+	const itemsPerProducer = 20000
+
+	rb, err := NewRingBuffer[int](capacity)
+	if err != nil {
+		t.Fatalf("Failed to create ring buffer: %v", err)
+	}
+
+	var produced, consumed uint64
+	var producersDone int32
+	var producerWG, consumerWG sync.WaitGroup
+
+	for p := 0; p < numProducers; p++ {
+		producerWG.Add(1)
+		go func() {
+			defer producerWG.Done()
+			for i := 0; i < itemsPerProducer; i++ {
+				for rb.Enqueue(i) != nil {
+					// Buffer full; retry until a consumer frees a slot.
+				}
+				atomic.AddUint64(&produced, 1)
+			}
+		}()
+	}
+
+	for c := 0; c < numConsumers; c++ {
+		consumerWG.Add(1)
+		go func() {
+			defer consumerWG.Done()
+			for {
+				_, err := rb.Dequeue()
+				if err == nil {
+					atomic.AddUint64(&consumed, 1)
+					continue
+				}
+				if atomic.LoadInt32(&producersDone) == 1 {
+					return
+				}
+			}
+		}()
+	}
+
+	producerWG.Wait()
+	atomic.StoreInt32(&producersDone, 1)
+	consumerWG.Wait()
+
+	if produced != uint64(numProducers*itemsPerProducer) {
+		t.Fatalf("produced %d, want %d", produced, numProducers*itemsPerProducer)
+	}
+	if consumed != produced {
+		t.Fatalf("consumed %d, want %d (produced)", consumed, produced)
+	}
+	if size := rb.Size(); size != 0 {
+		t.Fatalf("Size() == %d after full drain, want 0", size)
+	}
+}
+
+// TestRingBufferConcurrentOverwrite exercises the overwrite-on-full path
+// (chunk1-3's NewOverwriting) under concurrent producers and a consumer,
+// checking that Size() never exceeds Capacity() and never underflows even
+// while evictOldest is racing a concurrent Dequeue on the same slot.
+func TestRingBufferConcurrentOverwrite(t *testing.T) {
+	const capacity = 64
+	const numProducers = 8
+	const itemsPerProducer = 20000
+
+	rb, err := NewOverwriting[int](capacity)
+	if err != nil {
+		t.Fatalf("Failed to create overwriting ring buffer: %v", err)
+	}
+
+	var consumed uint64
+	var producersDone int32
+	var producerWG, consumerWG sync.WaitGroup
+
+	for p := 0; p < numProducers; p++ {
+		producerWG.Add(1)
+		go func() {
+			defer producerWG.Done()
+			for i := 0; i < itemsPerProducer; i++ {
+				if err := rb.Enqueue(i); err != nil {
+					t.Errorf("unexpected Enqueue error in overwrite mode: %v", err)
+				}
+				if size := rb.Size(); size > rb.Capacity() {
+					t.Errorf("Size() %d exceeds Capacity() %d", size, rb.Capacity())
+				}
+			}
+		}()
+	}
+
+	consumerWG.Add(1)
+	go func() {
+		defer consumerWG.Done()
+		for {
+			_, err := rb.Dequeue()
+			if err == nil {
+				atomic.AddUint64(&consumed, 1)
+				continue
+			}
+			if atomic.LoadInt32(&producersDone) == 1 {
+				return
+			}
+		}
+	}()
+
+	producerWG.Wait()
+	atomic.StoreInt32(&producersDone, 1)
+	consumerWG.Wait()
+
+	produced := uint64(numProducers * itemsPerProducer)
+	dropped := rb.Dropped()
+	remaining := rb.Size()
+	if produced != consumed+dropped+remaining {
+		t.Fatalf("produced %d != consumed %d + dropped %d + remaining %d", produced, consumed, dropped, remaining)
+	}
+}
+
+// FuzzRingBuffer seeds sequences of op-codes and replays them against the
+// ring buffer, asserting the FIFO order, Size and Capacity invariants of
+// TestRingBufferRandomOps (IsEmpty, IsFull and GetAt are not checked here).
+// Each byte in ops picks Enqueue (even) or Dequeue (odd) for that step.
+func FuzzRingBuffer(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 1, 1, 0})
+	f.Add([]byte{0, 0, 0, 1, 1, 1})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		rb, err := NewRingBuffer[int](64)
+		if err != nil {
+			t.Fatalf("Failed to create ring buffer: %v", err)
+		}
+
+		var writtenSoFar, readSoFar uint64
+		var fifo []int
+		next := 0
+
+		for _, op := range ops {
+			if op%2 == 0 {
+				if err := rb.Enqueue(next); err == nil {
+					writtenSoFar++
+					fifo = append(fifo, next)
+					next++
+				} else if !errors.Is(err, ErrRingBufferFull) {
+					t.Fatalf("unexpected Enqueue error: %v", err)
+				}
+			} else {
+				item, err := rb.Dequeue()
+				if err == nil {
+					readSoFar++
+					if len(fifo) == 0 || fifo[0] != item {
+						t.Fatalf("FIFO order violated: got %d, want %d", item, fifo[0])
+					}
+					fifo = fifo[1:]
+				} else if !errors.Is(err, ErrRingBufferEmpty) {
+					t.Fatalf("unexpected Dequeue error: %v", err)
+				}
+			}
+
+			size := rb.Size()
+			if size != writtenSoFar-readSoFar {
+				t.Fatalf("Size() == %d, want %d", size, writtenSoFar-readSoFar)
+			}
+			if size > rb.Capacity() {
+				t.Fatalf("Size() %d exceeds Capacity() %d", size, rb.Capacity())
+			}
+		}
+	})
+}
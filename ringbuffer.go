@@ -1,57 +1,131 @@
 package easyringbuffer
 
 import (
-	"errors"
+	"context"
+	"reflect"
 	"sync"
 )
 
-// Error messages for ring buffer operations.
-var (
-	ErrRingBufferFull  = errors.New("ring buffer is full")
-	ErrRingBufferEmpty = errors.New("ring buffer is empty")
-)
+// Resettable is implemented by values that need to release internal
+// resources before their ring buffer slot is reused, such as pooled
+// buffers, bytes.Buffers, or connection wrappers.
+type Resettable interface {
+	Reset()
+}
 
-// RingBuffer is a thread-safe ring buffer implementation.
-type RingBuffer[T any] struct {
-	buffer   []T
-	capacity int
-	size     int
-	head     int
-	tail     int
-	mu       sync.Mutex
+// resetItem calls Reset on item if it (or a pointer to it) implements
+// Resettable, reporting whether it did so. A typed-nil pointer satisfies
+// Resettable without a live receiver behind it, so it is skipped rather
+// than passed to Reset, which would otherwise panic on slots that were
+// dequeued or never written.
+func resetItem[T any](item T) bool {
+	if r, ok := any(item).(Resettable); ok {
+		if isNilResettable(r) {
+			return false
+		}
+		r.Reset()
+		return true
+	}
+	if r, ok := any(&item).(Resettable); ok {
+		r.Reset()
+		return true
+	}
+	return false
 }
 
-// New creates a new ring buffer with the specified capacity.
-func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+// isNilResettable reports whether r wraps a nil pointer, in which case
+// calling its Reset method would dereference a nil receiver.
+func isNilResettable(r Resettable) bool {
+	v := reflect.ValueOf(r)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// MutexRingBuffer is a thread-safe ring buffer implementation.
+type MutexRingBuffer[T any] struct {
+	buffer    []T
+	capacity  int
+	size      int
+	head      int
+	tail      int
+	overwrite bool
+	closed    bool
+	newItem   func() T
+	mu        sync.Mutex
+	notFull   *sync.Cond
+	notEmpty  *sync.Cond
+}
+
+// Option configures a MutexRingBuffer at construction time.
+type Option[T any] func(*MutexRingBuffer[T])
+
+// WithOverwrite configures Enqueue to evict the oldest item and overwrite
+// it instead of returning ErrRingBufferFull once the buffer is full.
+func WithOverwrite[T any](overwrite bool) Option[T] {
+	return func(rb *MutexRingBuffer[T]) {
+		rb.overwrite = overwrite
+	}
+}
+
+// NewMutexRingBuffer creates a new ring buffer with the specified capacity.
+func NewMutexRingBuffer[T any](capacity int, opts ...Option[T]) *MutexRingBuffer[T] {
 	if capacity <= 0 {
 		panic("capacity must be greater than 0")
 	}
-	return &RingBuffer[T]{
+	rb := &MutexRingBuffer[T]{
 		buffer:   make([]T, capacity),
 		capacity: capacity,
 	}
+	for _, opt := range opts {
+		opt(rb)
+	}
+	rb.notFull = sync.NewCond(&rb.mu)
+	rb.notEmpty = sync.NewCond(&rb.mu)
+	return rb
+}
+
+// NewMutexRingBufferWithPool creates a new ring buffer whose slots start out
+// holding values from newItem, and whose slots freed by Dequeue, DequeueWait
+// or Reset are refilled by calling newItem instead of being zeroed. Enqueue
+// overwrites the slot with the caller's item; the pool-created value in it
+// is not handed back to the caller.
+func NewMutexRingBufferWithPool[T any](capacity int, newItem func() T) *MutexRingBuffer[T] {
+	rb := NewMutexRingBuffer[T](capacity)
+	rb.newItem = newItem
+	for i := range rb.buffer {
+		rb.buffer[i] = newItem()
+	}
+	return rb
 }
 
 // Enqueue adds an item to the ring buffer.
-// Returns an error if the buffer is full.
-func (rb *RingBuffer[T]) Enqueue(item T) error {
+// Returns an error if the buffer is full, unless WithOverwrite was set, in
+// which case the oldest item is evicted to make room.
+func (rb *MutexRingBuffer[T]) Enqueue(item T) error {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
 	if rb.size == rb.capacity {
-		return ErrRingBufferFull
+		if !rb.overwrite {
+			return ErrRingBufferFull
+		}
+		var zeroValue T
+		resetItem(rb.buffer[rb.head])
+		rb.buffer[rb.head] = zeroValue
+		rb.head = (rb.head + 1) % rb.capacity
+		rb.size--
 	}
 
 	rb.buffer[rb.tail] = item
 	rb.tail = (rb.tail + 1) % rb.capacity
 	rb.size++
+	rb.notEmpty.Broadcast()
 
 	return nil
 }
 
 // Dequeue removes and returns the oldest item from the ring buffer.
 // Returns an error if the buffer is empty.
-func (rb *RingBuffer[T]) Dequeue() (T, error) {
+func (rb *MutexRingBuffer[T]) Dequeue() (T, error) {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -60,17 +134,145 @@ func (rb *RingBuffer[T]) Dequeue() (T, error) {
 		return zeroValue, ErrRingBufferEmpty
 	}
 
+	// The dequeued item is handed back to the caller, so it must not be
+	// reset here; only slots that are discarded without ever being
+	// returned (overwrite eviction, whole-ring Reset) call resetItem.
 	item := rb.buffer[rb.head]
-	// Optional: Clear the slot for garbage collection.
-	rb.buffer[rb.head] = zeroValue
+	if rb.newItem != nil {
+		rb.buffer[rb.head] = rb.newItem()
+	} else {
+		rb.buffer[rb.head] = zeroValue
+	}
 	rb.head = (rb.head + 1) % rb.capacity
 	rb.size--
+	rb.notFull.Broadcast()
 
 	return item, nil
 }
 
+// PushOverwrite adds an item to the ring buffer regardless of the
+// WithOverwrite setting, evicting the oldest item when the buffer is full.
+// It reports the evicted item and whether an eviction actually happened.
+func (rb *MutexRingBuffer[T]) PushOverwrite(item T) (evicted T, hadEviction bool) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if rb.size == rb.capacity {
+		var zeroValue T
+		evicted = rb.buffer[rb.head]
+		rb.buffer[rb.head] = zeroValue
+		rb.head = (rb.head + 1) % rb.capacity
+		rb.size--
+		hadEviction = true
+	}
+
+	rb.buffer[rb.tail] = item
+	rb.tail = (rb.tail + 1) % rb.capacity
+	rb.size++
+	rb.notEmpty.Broadcast()
+
+	return evicted, hadEviction
+}
+
+// EnqueueWait adds an item to the ring buffer, blocking until a slot frees
+// up, ctx is done, or the buffer is closed.
+func (rb *MutexRingBuffer[T]) EnqueueWait(ctx context.Context, item T) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			rb.mu.Lock()
+			rb.notFull.Broadcast()
+			rb.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	for rb.size == rb.capacity && !rb.closed {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rb.notFull.Wait()
+	}
+	if rb.closed {
+		return ErrRingBufferClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rb.buffer[rb.tail] = item
+	rb.tail = (rb.tail + 1) % rb.capacity
+	rb.size++
+	rb.notEmpty.Broadcast()
+
+	return nil
+}
+
+// DequeueWait removes and returns the oldest item from the ring buffer,
+// blocking until an item is available, ctx is done, or the buffer is
+// closed and drained.
+func (rb *MutexRingBuffer[T]) DequeueWait(ctx context.Context) (T, error) {
+	var zeroValue T
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			rb.mu.Lock()
+			rb.notEmpty.Broadcast()
+			rb.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	for rb.size == 0 && !rb.closed {
+		if err := ctx.Err(); err != nil {
+			return zeroValue, err
+		}
+		rb.notEmpty.Wait()
+	}
+	if rb.size == 0 && rb.closed {
+		return zeroValue, ErrRingBufferClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return zeroValue, err
+	}
+
+	item := rb.buffer[rb.head]
+	if rb.newItem != nil {
+		rb.buffer[rb.head] = rb.newItem()
+	} else {
+		rb.buffer[rb.head] = zeroValue
+	}
+	rb.head = (rb.head + 1) % rb.capacity
+	rb.size--
+	rb.notFull.Broadcast()
+
+	return item, nil
+}
+
+// Close marks the ring buffer as closed, waking any blocked EnqueueWait or
+// DequeueWait callers so they return ErrRingBufferClosed once drained.
+func (rb *MutexRingBuffer[T]) Close() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	rb.closed = true
+	rb.notFull.Broadcast()
+	rb.notEmpty.Broadcast()
+}
+
 // GetAll returns all items in the buffer in order from oldest to newest.
-func (rb *RingBuffer[T]) GetAll() []T {
+func (rb *MutexRingBuffer[T]) GetAll() []T {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -83,7 +285,7 @@ func (rb *RingBuffer[T]) GetAll() []T {
 }
 
 // GetLastN returns the last N items from the buffer.
-func (rb *RingBuffer[T]) GetLastN(n int) []T {
+func (rb *MutexRingBuffer[T]) GetLastN(n int) []T {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -101,7 +303,7 @@ func (rb *RingBuffer[T]) GetLastN(n int) []T {
 
 // Peek returns the next item without removing it from the buffer.
 // Returns an error if the buffer is empty.
-func (rb *RingBuffer[T]) Peek() (T, error) {
+func (rb *MutexRingBuffer[T]) Peek() (T, error) {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -114,7 +316,7 @@ func (rb *RingBuffer[T]) Peek() (T, error) {
 }
 
 // Len returns the current number of items in the buffer.
-func (rb *RingBuffer[T]) Len() int {
+func (rb *MutexRingBuffer[T]) Len() int {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -122,12 +324,12 @@ func (rb *RingBuffer[T]) Len() int {
 }
 
 // Capacity returns the capacity of the ring buffer.
-func (rb *RingBuffer[T]) Capacity() int {
+func (rb *MutexRingBuffer[T]) Capacity() int {
 	return rb.capacity
 }
 
 // IsEmpty checks if the ring buffer is empty.
-func (rb *RingBuffer[T]) IsEmpty() bool {
+func (rb *MutexRingBuffer[T]) IsEmpty() bool {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -135,7 +337,7 @@ func (rb *RingBuffer[T]) IsEmpty() bool {
 }
 
 // IsFull checks if the ring buffer is full.
-func (rb *RingBuffer[T]) IsFull() bool {
+func (rb *MutexRingBuffer[T]) IsFull() bool {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
@@ -143,13 +345,18 @@ func (rb *RingBuffer[T]) IsFull() bool {
 }
 
 // Reset clears all items in the ring buffer.
-func (rb *RingBuffer[T]) Reset() {
+func (rb *MutexRingBuffer[T]) Reset() {
 	rb.mu.Lock()
 	defer rb.mu.Unlock()
 
 	var zeroValue T
 	for i := 0; i < rb.capacity; i++ {
-		rb.buffer[i] = zeroValue
+		resetItem(rb.buffer[i])
+		if rb.newItem != nil {
+			rb.buffer[i] = rb.newItem()
+		} else {
+			rb.buffer[i] = zeroValue
+		}
 	}
 	rb.size = 0
 	rb.head = 0
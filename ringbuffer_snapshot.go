@@ -0,0 +1,147 @@
+package easyringbuffer
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+)
+
+// snapshotHeader precedes the encoded items in a Snapshot so Restore and
+// LoadMutexRingBuffer know how many items to expect and what capacity to use.
+type snapshotHeader struct {
+	Capacity int
+	Head     int
+	Tail     int
+	Size     int
+}
+
+// Snapshot writes the ring buffer's capacity and live items, oldest to
+// newest, to w so it can later be rehydrated with Restore or
+// LoadMutexRingBuffer. MutexRingBuffer[byte] and MutexRingBuffer[float64] use dedicated
+// fast paths instead of encoding/gob.
+func (rb *MutexRingBuffer[T]) Snapshot(w io.Writer) error {
+	rb.mu.Lock()
+	items := make([]T, rb.size)
+	for i := 0; i < rb.size; i++ {
+		items[i] = rb.buffer[(rb.head+i)%rb.capacity]
+	}
+	header := snapshotHeader{
+		Capacity: rb.capacity,
+		Head:     rb.head,
+		Tail:     rb.tail,
+		Size:     rb.size,
+	}
+	rb.mu.Unlock()
+
+	if err := gob.NewEncoder(w).Encode(header); err != nil {
+		return err
+	}
+	return encodeSnapshotItems(w, items)
+}
+
+// encodeSnapshotItems writes items to w, preferring raw bytes or
+// little-endian float64s when T allows it, and falling back to gob for
+// arbitrary T.
+func encodeSnapshotItems[T any](w io.Writer, items []T) error {
+	if bs, ok := any(items).([]byte); ok {
+		_, err := w.Write(bs)
+		return err
+	}
+	if fs, ok := any(items).([]float64); ok {
+		buf := make([]byte, 8)
+		for _, f := range fs {
+			binary.LittleEndian.PutUint64(buf, math.Float64bits(f))
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return gob.NewEncoder(w).Encode(items)
+}
+
+// decodeSnapshotItems reads n items from r, mirroring the fast paths
+// encodeSnapshotItems uses for T == byte and T == float64.
+func decodeSnapshotItems[T any](r io.Reader, n int) ([]T, error) {
+	var zeroValue T
+	switch any(zeroValue).(type) {
+	case byte:
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return any(buf).([]T), nil
+	case float64:
+		floats := make([]float64, n)
+		buf := make([]byte, 8)
+		for i := range floats {
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return nil, err
+			}
+			floats[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf))
+		}
+		return any(floats).([]T), nil
+	default:
+		items := make([]T, n)
+		if err := gob.NewDecoder(r).Decode(&items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
+
+// Restore replaces the ring buffer's contents with a snapshot previously
+// written by Snapshot. The buffer's own capacity is kept; it is an error
+// for the snapshot to hold more items than that capacity.
+func (rb *MutexRingBuffer[T]) Restore(r io.Reader) error {
+	var header snapshotHeader
+	if err := gob.NewDecoder(r).Decode(&header); err != nil {
+		return err
+	}
+
+	items, err := decodeSnapshotItems[T](r, header.Size)
+	if err != nil {
+		return err
+	}
+
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if header.Size > rb.capacity {
+		return errors.New("snapshot holds more items than the ring buffer capacity")
+	}
+
+	var zeroValue T
+	for i := range rb.buffer {
+		rb.buffer[i] = zeroValue
+	}
+	copy(rb.buffer, items)
+	rb.head = 0
+	rb.tail = header.Size % rb.capacity
+	rb.size = header.Size
+
+	return nil
+}
+
+// LoadMutexRingBuffer creates a new ring buffer sized to match a snapshot
+// previously written by Snapshot, and restores its contents from r.
+func LoadMutexRingBuffer[T any](r io.Reader) (*MutexRingBuffer[T], error) {
+	var header snapshotHeader
+	if err := gob.NewDecoder(r).Decode(&header); err != nil {
+		return nil, err
+	}
+
+	items, err := decodeSnapshotItems[T](r, header.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	rb := NewMutexRingBuffer[T](header.Capacity)
+	copy(rb.buffer, items)
+	rb.tail = header.Size % rb.capacity
+	rb.size = header.Size
+
+	return rb, nil
+}
@@ -10,7 +10,7 @@ import (
 
 func TestNewRingBuffer(t *testing.T) {
 	// Test creating a ring buffer with valid capacity
-	rb := easyringbuffer.New[int](10)
+	rb := easyringbuffer.NewMutexRingBuffer[int](10)
 	if rb == nil {
 		t.Fatal("Expected a new ring buffer instance, got nil")
 	}
@@ -24,11 +24,11 @@ func TestNewRingBuffer(t *testing.T) {
 			t.Fatal("Expected panic when creating ring buffer with capacity 0, but did not panic")
 		}
 	}()
-	easyringbuffer.New
+	easyringbuffer.NewMutexRingBuffer[int](0)
 }
 
 func TestEnqueueDequeue(t *testing.T) {
-	rb := easyringbuffer.New[int](5)
+	rb := easyringbuffer.NewMutexRingBuffer[int](5)
 
 	// Enqueue items
 	for i := 1; i <= 5; i++ {
@@ -73,7 +73,7 @@ func TestEnqueueDequeue(t *testing.T) {
 }
 
 func TestGetAllAndGetLastN(t *testing.T) {
-	rb := easyringbuffer.New[int](5)
+	rb := easyringbuffer.NewMutexRingBuffer[int](5)
 
 	// Enqueue items
 	for i := 1; i <= 5; i++ {
@@ -112,7 +112,7 @@ func TestGetAllAndGetLastN(t *testing.T) {
 }
 
 func TestPeek(t *testing.T) {
-	rb := easyringbuffer.New[int](5)
+	rb := easyringbuffer.NewMutexRingBuffer[int](5)
 
 	// Peek on empty buffer
 	_, err := rb.Peek()
@@ -146,7 +146,7 @@ func TestPeek(t *testing.T) {
 }
 
 func TestLenAndCapacity(t *testing.T) {
-	rb := easyringbuffer.New[int](5)
+	rb := easyringbuffer.NewMutexRingBuffer[int](5)
 
 	if rb.Len() != 0 {
 		t.Errorf("Expected Len() == 0, got %d", rb.Len())
@@ -171,7 +171,7 @@ func TestLenAndCapacity(t *testing.T) {
 }
 
 func TestIsEmptyAndIsFull(t *testing.T) {
-	rb := easyringbuffer.New[int](3)
+	rb := easyringbuffer.NewMutexRingBuffer[int](2)
 
 	if !rb.IsEmpty() {
 		t.Errorf("Expected IsEmpty() == true")
@@ -201,7 +201,7 @@ func TestIsEmptyAndIsFull(t *testing.T) {
 }
 
 func TestReset(t *testing.T) {
-	rb := easyringbuffer.New[int](10)
+	rb := easyringbuffer.NewMutexRingBuffer[int](10)
 
 	for i := 1; i <= 3; i++ {
 		_ = rb.Enqueue(i)
@@ -229,7 +229,7 @@ func TestReset(t *testing.T) {
 }
 
 func TestWrapAround(t *testing.T) {
-	rb := easyringbuffer.New[int](3)
+	rb := easyringbuffer.NewMutexRingBuffer[int](3)
 
 	_ = rb.Enqueue(1)
 	_ = rb.Enqueue(2)
@@ -254,7 +254,7 @@ func TestWrapAround(t *testing.T) {
 }
 
 func TestConcurrentAccess(t *testing.T) {
-	rb := easyringbuffer.New[int](5000)
+	rb := easyringbuffer.NewMutexRingBuffer[int](5000)
 
 	var wg sync.WaitGroup
 	numProducers := 5
@@ -339,7 +339,7 @@ func TestConcurrentAccess(t *testing.T) {
 
 func TestRingBufferGeneric(t *testing.T) {
 	// Test with strings
-	rb := easyringbuffer.New[string](3)
+	rb := easyringbuffer.NewMutexRingBuffer[string](3)
 	_ = rb.Enqueue("one")
 	_ = rb.Enqueue("two")
 	_ = rb.Enqueue("three")
@@ -357,7 +357,7 @@ func TestRingBufferGeneric(t *testing.T) {
 		ID   int
 		Name string
 	}
-	rbStruct := easyringbuffer.New[MyStruct](3)
+	rbStruct := easyringbuffer.NewMutexRingBuffer[MyStruct](3)
 	_ = rbStruct.Enqueue(MyStruct{ID: 1, Name: "Alice"})
 	_ = rbStruct.Enqueue(MyStruct{ID: 2, Name: "Bob"})
 
@@ -372,7 +372,7 @@ func TestRingBufferGeneric(t *testing.T) {
 
 func TestEnqueueNil(t *testing.T) {
 	// Note: Only applicable if T can be a pointer type
-	rb := easyringbuffer.New[*int](1)
+	rb := easyringbuffer.NewMutexRingBuffer[*int](1)
 
 	var ptr *int = nil
 	err := rb.Enqueue(ptr)